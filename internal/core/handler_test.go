@@ -0,0 +1,44 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHandlerRegistry_RegisterAndGet(t *testing.T) {
+	registry := NewHandlerRegistry()
+
+	custom := TaskType("custom_type")
+	handler := func(ctx context.Context, t *Task) (any, error) {
+		return "handled", nil
+	}
+
+	if _, ok := registry.Get(custom); ok {
+		t.Fatalf("expected no handler registered for %s before Register", custom)
+	}
+
+	registry.Register(custom, handler)
+
+	got, ok := registry.Get(custom)
+	if !ok {
+		t.Fatalf("expected handler registered for %s", custom)
+	}
+
+	result, err := got(context.Background(), &Task{})
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if result != "handled" {
+		t.Errorf("expected result %q, got %v", "handled", result)
+	}
+}
+
+func TestDefaultHandlerRegistry_HasBuiltins(t *testing.T) {
+	registry := DefaultHandlerRegistry()
+
+	for _, taskType := range []TaskType{CPU_INTENSIVE, IO_BOUND, TIME_BASED} {
+		if _, ok := registry.Get(taskType); !ok {
+			t.Errorf("expected a default handler registered for %s", taskType)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package core
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// NotifyShutdown returns a context derived from parent that is canceled
+// on SIGINT or SIGTERM, and the stop function to release the underlying
+// signal.Notify registration. Typical use:
+//
+//	ctx, stop := core.NotifyShutdown(context.Background())
+//	defer stop()
+//	pool.Start()
+//	<-ctx.Done()
+//	pool.Shutdown(shutdownCtx)
+func NotifyShutdown(parent context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+}
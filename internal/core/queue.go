@@ -1,37 +1,327 @@
 package core
 
-import "fmt"
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
 
+// numPriorityBands is the number of priority bands a TaskQueue schedules
+// across. Task.Priority is clamped into [0, numPriorityBands-1] by
+// priorityBand, where band 0 is the highest priority.
+const numPriorityBands = 4
+
+// priorityBandWeights gives each band's share of tasks drained per
+// dispatch cycle, band 0 (highest priority) through band 3 (lowest), so
+// higher-priority bands dominate without starving lower ones.
+var priorityBandWeights = [numPriorityBands]int{8, 4, 2, 1}
+
+// priorityBand maps a Task.Priority (higher is more urgent) onto a
+// dispatch band.
+func priorityBand(priority int) int {
+	switch {
+	case priority >= numPriorityBands-1:
+		return 0
+	case priority <= 0:
+		return numPriorityBands - 1
+	default:
+		return numPriorityBands - 1 - priority
+	}
+}
+
+// NumPriorityBands and PriorityBandWeights are exported so other Queue
+// implementations, such as RedisQueue, can dispatch across the same
+// priority bands with the same weighted round robin that TaskQueue uses.
+const NumPriorityBands = numPriorityBands
+
+var PriorityBandWeights = priorityBandWeights
+
+// PriorityBand maps a Task.Priority onto a dispatch band; see priorityBand.
+func PriorityBand(priority int) int {
+	return priorityBand(priority)
+}
+
+// BandStatus reports a single priority band's current depth and capacity,
+// as returned by TaskQueue.Status.
+type BandStatus struct {
+	Priority int
+	Current  int
+	Capacity int
+}
+
+// Queue is anything a WorkerPool or Retrier can submit tasks to and drain
+// tasks from. TaskQueue is the in-process implementation; RedisQueue (see
+// internal/store) backs the same interface with Redis so multiple
+// TaskEngine processes can share load.
+type Queue interface {
+	// Submit enqueues task into the queue's priority scheduling.
+	Submit(task *Task) error
+	// GetTaskChannel returns the channel workers read dispatched tasks
+	// from. It is closed once the queue is closed.
+	GetTaskChannel() <-chan Task
+	// Close stops the queue from dispatching further tasks and closes
+	// the channel returned by GetTaskChannel. It is safe to call more
+	// than once.
+	Close()
+	// Status reports each priority band's current depth and capacity.
+	Status() []BandStatus
+}
+
+// Heartbeater is optionally implemented by a Queue backend that needs
+// proof a task's worker is still alive, so an orphan-recovery process can
+// reclaim tasks abandoned by a crashed or partitioned worker. RedisQueue
+// implements it; TaskQueue does not need to, since an in-process worker
+// dying takes the whole process down with it.
+type Heartbeater interface {
+	// Heartbeat records that taskID is still being actively processed.
+	Heartbeat(taskID string) error
+}
+
+// Acker is optionally implemented by a Queue backend that tracks which
+// tasks are currently claimed, so a worker can tell it a task has
+// finished (successfully or not) and should no longer be eligible for
+// orphan recovery. RedisQueue implements it; TaskQueue does not need to,
+// since it doesn't track claimed tasks separately from its channels.
+type Acker interface {
+	// Ack records that taskID has finished processing.
+	Ack(taskID string) error
+}
+
+// delayedEntry is a task submitted via SubmitAt, awaiting release into its
+// priority band at a scheduled time.
+type delayedEntry struct {
+	task Task
+	at   time.Time
+}
+
+// delayedHeap is a min-heap of delayedEntry ordered by scheduled time.
+type delayedHeap []*delayedEntry
+
+func (h delayedHeap) Len() int           { return len(h) }
+func (h delayedHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h delayedHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *delayedHeap) Push(x interface{}) {
+	*h = append(*h, x.(*delayedEntry))
+}
+
+func (h *delayedHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	*h = old[:n-1]
+
+	return entry
+}
+
+// TaskQueue is a multi-level priority scheduler: one buffered channel per
+// priority band, plus a heap-backed set of tasks scheduled for future
+// release via SubmitAt. A dispatcher goroutine feeds a single outbound
+// channel (GetTaskChannel) by weighted round robin across the bands.
 type TaskQueue struct {
-	Tasks   chan Task
-	MaxSize int
+	bands   [numPriorityBands]chan Task
+	maxSize int
+
+	out       chan Task
+	quit      chan struct{}
+	closeOnce sync.Once
+
+	delayMu   sync.Mutex
+	delayed   delayedHeap
+	delayWake chan struct{}
 }
 
-func NewTaskQueue(capacity int) *TaskQueue {
-	tasksChan := make(chan Task, capacity)
+// NewTaskQueue starts a TaskQueue's dispatcher and delayed-release
+// goroutines. ctx additionally stops them when canceled, alongside an
+// explicit call to Close; a nil ctx behaves as context.Background().
+func NewTaskQueue(ctx context.Context, capacity int) *TaskQueue {
+	tq := &TaskQueue{
+		maxSize:   capacity,
+		out:       make(chan Task),
+		quit:      make(chan struct{}),
+		delayWake: make(chan struct{}, 1),
+	}
+
+	for i := range tq.bands {
+		tq.bands[i] = make(chan Task, capacity)
+	}
+
+	go tq.dispatch()
+	go tq.runDelayed()
 
-	taskQueue := TaskQueue{Tasks: tasksChan, MaxSize: capacity}
+	if ctx != nil {
+		go func() {
+			<-ctx.Done()
+			tq.Close()
+		}()
+	}
 
-	return &taskQueue
+	return tq
 }
 
+// Submit routes task into the priority band matching task.Priority.
 func (tq *TaskQueue) Submit(task *Task) error {
+	band := priorityBand(task.Priority)
+
 	select {
-	case tq.Tasks <- *task:
+	case tq.bands[band] <- *task:
 		return nil
 	default:
 		return fmt.Errorf("queue full, please try again shortly")
 	}
 }
 
+// SubmitAt schedules task for release into its priority band at when. If
+// when is not in the future, it is submitted immediately.
+func (tq *TaskQueue) SubmitAt(task *Task, when time.Time) error {
+	if !when.After(time.Now()) {
+		return tq.Submit(task)
+	}
+
+	tq.delayMu.Lock()
+	heap.Push(&tq.delayed, &delayedEntry{task: *task, at: when})
+	tq.delayMu.Unlock()
+
+	select {
+	case tq.delayWake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
 func (tq *TaskQueue) GetTaskChannel() <-chan Task {
-	return tq.Tasks
+	return tq.out
 }
 
+// Close stops the dispatcher and delayed-release goroutines and closes
+// the outbound channel returned by GetTaskChannel. It is safe to call
+// more than once (including when ctx passed to NewTaskQueue is also
+// canceled).
 func (tq *TaskQueue) Close() {
-	close(tq.Tasks)
+	tq.closeOnce.Do(func() { close(tq.quit) })
+}
+
+// Status reports each priority band's current depth and capacity.
+func (tq *TaskQueue) Status() []BandStatus {
+	statuses := make([]BandStatus, numPriorityBands)
+	for band := range tq.bands {
+		statuses[band] = BandStatus{Priority: band, Current: len(tq.bands[band]), Capacity: cap(tq.bands[band])}
+	}
+
+	return statuses
+}
+
+// Depth returns the total number of tasks currently buffered across all
+// priority bands. It does not include delayed tasks not yet due.
+func (tq *TaskQueue) Depth() int {
+	total := 0
+	for _, ch := range tq.bands {
+		total += len(ch)
+	}
+
+	return total
 }
 
-func (tq *TaskQueue) Status() (current, max int) {
-	return len(tq.Tasks), tq.MaxSize
+// dispatch feeds tq.out by weighted round robin across the priority
+// bands: each cycle drains up to priorityBandWeights[band] tasks from
+// band before moving to the next, so higher-priority bands dominate
+// without starving lower ones.
+func (tq *TaskQueue) dispatch() {
+	for {
+		sentAny := false
+
+		for band := range tq.bands {
+			weight := priorityBandWeights[band]
+
+		bandLoop:
+			for i := 0; i < weight; i++ {
+				select {
+				case <-tq.quit:
+					close(tq.out)
+					return
+				case task, ok := <-tq.bands[band]:
+					if !ok {
+						close(tq.out)
+						return
+					}
+
+					select {
+					case tq.out <- task:
+						sentAny = true
+					case <-tq.quit:
+						close(tq.out)
+						return
+					}
+				default:
+					break bandLoop
+				}
+			}
+		}
+
+		if !sentAny {
+			select {
+			case <-time.After(time.Millisecond):
+			case <-tq.quit:
+				close(tq.out)
+				return
+			}
+		}
+	}
+}
+
+// runDelayed wakes at the earliest scheduled SubmitAt time, releasing
+// every delayed task whose time has come into its priority band.
+func (tq *TaskQueue) runDelayed() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		tq.delayMu.Lock()
+		wait := time.Hour
+		if len(tq.delayed) > 0 {
+			wait = time.Until(tq.delayed[0].at)
+			if wait < 0 {
+				wait = 0
+			}
+		}
+		tq.delayMu.Unlock()
+
+		timer.Reset(wait)
+
+		select {
+		case <-timer.C:
+			tq.releaseDueDelayed()
+		case <-tq.delayWake:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-tq.quit:
+			return
+		}
+	}
+}
+
+func (tq *TaskQueue) releaseDueDelayed() {
+	now := time.Now()
+
+	for {
+		tq.delayMu.Lock()
+		if len(tq.delayed) == 0 || tq.delayed[0].at.After(now) {
+			tq.delayMu.Unlock()
+			return
+		}
+
+		entry := heap.Pop(&tq.delayed).(*delayedEntry)
+		tq.delayMu.Unlock()
+
+		task := entry.task
+		if err := tq.Submit(&task); err != nil {
+			tq.delayMu.Lock()
+			heap.Push(&tq.delayed, &delayedEntry{task: task, at: now.Add(time.Second)})
+			tq.delayMu.Unlock()
+		}
+	}
 }
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPriorityBand(t *testing.T) {
+	testCases := []struct {
+		priority int
+		band     int
+	}{
+		{priority: 3, band: 0},
+		{priority: 10, band: 0},
+		{priority: 2, band: 1},
+		{priority: 1, band: 2},
+		{priority: 0, band: 3},
+		{priority: -5, band: 3},
+	}
+
+	for _, tc := range testCases {
+		if got := priorityBand(tc.priority); got != tc.band {
+			t.Errorf("priorityBand(%d) = %d, want %d", tc.priority, got, tc.band)
+		}
+	}
+}
+
+func TestTaskQueue_Submit_RoutesByPriority(t *testing.T) {
+	tq := NewTaskQueue(context.Background(), 4)
+	defer tq.Close()
+
+	high, _ := NewTask(CPU_INTENSIVE, map[string]string{"k": "v"})
+	high.Priority = 3
+
+	low, _ := NewTask(CPU_INTENSIVE, map[string]string{"k": "v"})
+	low.Priority = 0
+
+	if err := tq.Submit(high); err != nil {
+		t.Fatalf("Submit high returned error: %v", err)
+	}
+	if err := tq.Submit(low); err != nil {
+		t.Fatalf("Submit low returned error: %v", err)
+	}
+
+	statuses := tq.Status()
+	if statuses[0].Current != 1 {
+		t.Errorf("expected 1 task in band 0, got %d", statuses[0].Current)
+	}
+	if statuses[3].Current != 1 {
+		t.Errorf("expected 1 task in band 3, got %d", statuses[3].Current)
+	}
+}
+
+func TestTaskQueue_HigherPriorityDispatchedFirst(t *testing.T) {
+	tq := NewTaskQueue(context.Background(), 16)
+	defer tq.Close()
+
+	low, _ := NewTask(CPU_INTENSIVE, map[string]string{"k": "v"})
+	low.Priority = 0
+	low.ID = "low"
+
+	high, _ := NewTask(CPU_INTENSIVE, map[string]string{"k": "v"})
+	high.Priority = 3
+	high.ID = "high"
+
+	if err := tq.Submit(low); err != nil {
+		t.Fatalf("Submit low returned error: %v", err)
+	}
+	if err := tq.Submit(high); err != nil {
+		t.Fatalf("Submit high returned error: %v", err)
+	}
+
+	select {
+	case task := <-tq.GetTaskChannel():
+		if task.ID != "high" {
+			t.Errorf("expected high-priority task dispatched first, got %s", task.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for dispatched task")
+	}
+}
+
+func TestTaskQueue_SubmitAt_ReleasesAfterDelay(t *testing.T) {
+	tq := NewTaskQueue(context.Background(), 4)
+	defer tq.Close()
+
+	task, _ := NewTask(CPU_INTENSIVE, map[string]string{"k": "v"})
+
+	if err := tq.SubmitAt(task, time.Now().Add(20*time.Millisecond)); err != nil {
+		t.Fatalf("SubmitAt returned error: %v", err)
+	}
+
+	select {
+	case <-tq.GetTaskChannel():
+		t.Fatal("task released before its scheduled time")
+	case <-time.After(5 * time.Millisecond):
+	}
+
+	select {
+	case released := <-tq.GetTaskChannel():
+		if released.ID != task.ID {
+			t.Errorf("expected released task %s, got %s", task.ID, released.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delayed task to release")
+	}
+}
@@ -0,0 +1,23 @@
+package core
+
+// Metrics captures measured resource usage for a single task execution.
+// The default CPU_INTENSIVE and IO_BOUND handlers (see
+// DefaultHandlerRegistry) populate it via runMeasured, which is
+// implemented per-OS: a cgroup v2 slice on Linux; on every other OS only
+// WallTimeNs is filled in, and CPUTimeNs, MaxRSSBytes and PageFaults stay
+// zero.
+type Metrics struct {
+	CPUTimeNs   int64 `json:"cpu_time_ns"`
+	MaxRSSBytes int64 `json:"max_rss_bytes"`
+	PageFaults  int64 `json:"page_faults"`
+	WallTimeNs  int64 `json:"wall_time_ns"`
+}
+
+// runMeasured runs fn and returns whatever it produced alongside Metrics
+// describing the resources fn consumed while running. workerID scopes the
+// measurement (a cgroup slice path, on Linux) to the calling worker.
+//
+// Implemented in resource_linux.go, resource_unix.go, and
+// resource_windows.go:
+//
+//	func runMeasured(workerID string, fn func() (json.RawMessage, error)) (json.RawMessage, Metrics, error)
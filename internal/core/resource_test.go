@@ -0,0 +1,40 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunMeasured_ReturnsDataAndWallTime(t *testing.T) {
+	sleep := 5 * time.Millisecond
+
+	data, metrics, err := runMeasured("worker_test", func() (json.RawMessage, error) {
+		time.Sleep(sleep)
+		return json.RawMessage(`{"ok": true}`), nil
+	})
+	if err != nil {
+		t.Fatalf("runMeasured returned error: %v", err)
+	}
+
+	if string(data) != `{"ok": true}` {
+		t.Errorf("expected data to pass through unchanged, got %s", data)
+	}
+
+	if metrics.WallTimeNs < sleep.Nanoseconds() {
+		t.Errorf("expected WallTimeNs >= %d, got %d", sleep.Nanoseconds(), metrics.WallTimeNs)
+	}
+}
+
+func TestRunMeasured_PropagatesError(t *testing.T) {
+	wantErr := errors.New("workload failed")
+
+	_, _, err := runMeasured("worker_test", func() (json.RawMessage, error) {
+		return nil, wantErr
+	})
+
+	if err != wantErr {
+		t.Errorf("expected error %v, got %v", wantErr, err)
+	}
+}
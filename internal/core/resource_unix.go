@@ -0,0 +1,24 @@
+//go:build !linux && !windows
+
+package core
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// runMeasured runs fn and reports only wall time. Per-task CPU/memory
+// accounting needs getrusage(RUSAGE_THREAD) to isolate the task's own
+// thread from the rest of the process, and RUSAGE_THREAD isn't portable
+// beyond Linux (where resource_linux.go's cgroup-backed accounting is
+// used instead). RUSAGE_SELF is process-wide, so on a multi-worker pool
+// it would report every other in-flight task's CPU time and faults as
+// this task's own cost; better to leave those fields unset than report
+// a misleading number.
+func runMeasured(workerID string, fn func() (json.RawMessage, error)) (json.RawMessage, Metrics, error) {
+	wallStart := time.Now()
+
+	data, err := fn()
+
+	return data, Metrics{WallTimeNs: time.Since(wallStart).Nanoseconds()}, err
+}
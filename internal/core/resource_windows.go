@@ -0,0 +1,18 @@
+//go:build windows
+
+package core
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// runMeasured runs fn and reports only wall time: neither cgroup v2 nor
+// getrusage is available on Windows.
+func runMeasured(workerID string, fn func() (json.RawMessage, error)) (json.RawMessage, Metrics, error) {
+	wallStart := time.Now()
+
+	data, err := fn()
+
+	return data, Metrics{WallTimeNs: time.Since(wallStart).Nanoseconds()}, err
+}
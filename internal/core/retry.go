@@ -0,0 +1,203 @@
+package core
+
+import (
+	"container/heap"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RetryDelayFunc computes how long to wait before re-enqueuing a task
+// that failed or timed out. retried is the number of attempts already
+// made (0 on the first failure).
+type RetryDelayFunc func(retried int, err error, task *Task) time.Duration
+
+// DefaultRetryDelay returns a RetryDelayFunc implementing exponential
+// backoff with jitter: min(2^retried * base, cap) + rand(0, base).
+func DefaultRetryDelay(base, cap time.Duration) RetryDelayFunc {
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	if cap <= 0 {
+		cap = 30 * time.Second
+	}
+
+	return func(retried int, err error, task *Task) time.Duration {
+		delay := cap
+		if retried < 62 {
+			if scaled := base << uint(retried); scaled > 0 && scaled < cap {
+				delay = scaled
+			}
+		}
+
+		return delay + time.Duration(rand.Int63n(int64(base)+1))
+	}
+}
+
+// DefaultRetryDelayFunc is the RetryDelayFunc a Retrier falls back to when
+// none is supplied: exponential backoff from 500ms capped at 30s, plus
+// jitter.
+var DefaultRetryDelayFunc = DefaultRetryDelay(500*time.Millisecond, 30*time.Second)
+
+// retryEntry is a task awaiting re-submission at a scheduled time.
+type retryEntry struct {
+	task Task
+	at   time.Time
+}
+
+// retryHeap is a min-heap of retryEntry ordered by scheduled time, giving
+// the Retrier's scheduler goroutine O(log n) access to the next due task.
+type retryHeap []*retryEntry
+
+func (h retryHeap) Len() int           { return len(h) }
+func (h retryHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h retryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *retryHeap) Push(x interface{}) {
+	*h = append(*h, x.(*retryEntry))
+}
+
+func (h *retryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	*h = old[:n-1]
+
+	return entry
+}
+
+// Retrier re-enqueues FAILED/TIMEOUT tasks onto a Queue after a computed
+// backoff delay, moving tasks that exceed their MaxRetry to a dead queue
+// for later inspection or replay.
+type Retrier struct {
+	queue     Queue
+	dead      Queue
+	delayFunc RetryDelayFunc
+
+	mu      sync.Mutex
+	pending retryHeap
+	wake    chan struct{}
+	quit    chan struct{}
+}
+
+// NewRetrier wires a Retrier to re-submit retried tasks onto queue and
+// move exhausted ones onto dead. A nil delayFunc falls back to
+// DefaultRetryDelayFunc. The scheduler goroutine starts immediately and
+// runs until Stop is called.
+func NewRetrier(queue, dead Queue, delayFunc RetryDelayFunc) *Retrier {
+	if delayFunc == nil {
+		delayFunc = DefaultRetryDelayFunc
+	}
+
+	r := &Retrier{
+		queue:     queue,
+		dead:      dead,
+		delayFunc: delayFunc,
+		wake:      make(chan struct{}, 1),
+		quit:      make(chan struct{}),
+	}
+
+	go r.run()
+
+	return r
+}
+
+// Handle inspects result and, if it reflects a FAILED or TIMEOUT task,
+// either schedules a retry after the configured backoff or, once MaxRetry
+// is exhausted, moves the task onto the dead queue. Results for any other
+// status are ignored.
+func (r *Retrier) Handle(task Task, result TaskResult) error {
+	if result.Status != FAILED && result.Status != TIMEOUT {
+		return nil
+	}
+
+	task.Status = result.Status
+	task.LastError = result.Error
+
+	if task.Retried >= task.MaxRetry {
+		return r.dead.Submit(&task)
+	}
+
+	delayFunc := task.RetryDelay
+	if delayFunc == nil {
+		delayFunc = r.delayFunc
+	}
+
+	delay := delayFunc(task.Retried, errors.New(result.Error), &task)
+	task.Retried++
+
+	r.schedule(task, time.Now().Add(delay))
+
+	return nil
+}
+
+func (r *Retrier) schedule(task Task, at time.Time) {
+	r.mu.Lock()
+	heap.Push(&r.pending, &retryEntry{task: task, at: at})
+	r.mu.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run wakes at the earliest pending retry time, re-submitting every task
+// whose time has come, and otherwise blocks until a new retry is
+// scheduled or Stop is called.
+func (r *Retrier) run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		r.mu.Lock()
+		wait := time.Hour
+		if len(r.pending) > 0 {
+			wait = time.Until(r.pending[0].at)
+			if wait < 0 {
+				wait = 0
+			}
+		}
+		r.mu.Unlock()
+
+		timer.Reset(wait)
+
+		select {
+		case <-timer.C:
+			r.releaseDue()
+		case <-r.wake:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// releaseDue re-submits every pending task scheduled at or before now.
+func (r *Retrier) releaseDue() {
+	now := time.Now()
+
+	for {
+		r.mu.Lock()
+		if len(r.pending) == 0 || r.pending[0].at.After(now) {
+			r.mu.Unlock()
+			return
+		}
+
+		entry := heap.Pop(&r.pending).(*retryEntry)
+		r.mu.Unlock()
+
+		task := entry.task
+		if err := r.queue.Submit(&task); err != nil {
+			r.schedule(task, now.Add(time.Second))
+		}
+	}
+}
+
+// Stop halts the scheduler goroutine. Pending retries are discarded.
+func (r *Retrier) Stop() {
+	close(r.quit)
+}
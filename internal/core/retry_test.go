@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDefaultRetryDelay_GrowsAndCaps(t *testing.T) {
+	base := 10 * time.Millisecond
+	cap := 100 * time.Millisecond
+	delayFunc := DefaultRetryDelay(base, cap)
+
+	first := delayFunc(0, nil, &Task{})
+	if first < base {
+		t.Errorf("expected first delay >= base (%v), got %v", base, first)
+	}
+
+	capped := delayFunc(10, nil, &Task{})
+	if capped < cap || capped > cap+base {
+		t.Errorf("expected capped delay in [%v, %v], got %v", cap, cap+base, capped)
+	}
+}
+
+func TestRetrier_Handle_SchedulesRetry(t *testing.T) {
+	queue := NewTaskQueue(context.Background(), 1)
+	dead := NewTaskQueue(context.Background(), 1)
+	defer queue.Close()
+	defer dead.Close()
+
+	retrier := NewRetrier(queue, dead, DefaultRetryDelay(1*time.Millisecond, 5*time.Millisecond))
+	defer retrier.Stop()
+
+	task := Task{ID: "retry_me", Type: CPU_INTENSIVE, MaxRetry: 2}
+	result := TaskResult{TaskID: task.ID, Status: FAILED, Error: "boom"}
+
+	if err := retrier.Handle(task, result); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	select {
+	case retried := <-queue.GetTaskChannel():
+		if retried.ID != task.ID {
+			t.Errorf("expected retried task %s, got %s", task.ID, retried.ID)
+		}
+		if retried.Retried != 1 {
+			t.Errorf("expected Retried to be incremented to 1, got %d", retried.Retried)
+		}
+		if retried.LastError != "boom" {
+			t.Errorf("expected LastError to be recorded, got %q", retried.LastError)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for retried task")
+	}
+}
+
+func TestRetrier_Handle_DeadLettersAfterMaxRetry(t *testing.T) {
+	queue := NewTaskQueue(context.Background(), 1)
+	dead := NewTaskQueue(context.Background(), 1)
+	defer queue.Close()
+	defer dead.Close()
+
+	retrier := NewRetrier(queue, dead, nil)
+	defer retrier.Stop()
+
+	task := Task{ID: "exhausted", Type: CPU_INTENSIVE, MaxRetry: 1, Retried: 1}
+	result := TaskResult{TaskID: task.ID, Status: TIMEOUT, Error: "took too long"}
+
+	if err := retrier.Handle(task, result); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	select {
+	case deadTask := <-dead.GetTaskChannel():
+		if deadTask.ID != task.ID {
+			t.Errorf("expected dead-lettered task %s, got %s", task.ID, deadTask.ID)
+		}
+		if deadTask.Status != TIMEOUT {
+			t.Errorf("expected dead-lettered status TIMEOUT, got %v", deadTask.Status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for dead-lettered task")
+	}
+}
+
+func TestRetrier_Handle_IgnoresSuccess(t *testing.T) {
+	queue := NewTaskQueue(context.Background(), 1)
+	dead := NewTaskQueue(context.Background(), 1)
+	defer queue.Close()
+	defer dead.Close()
+
+	retrier := NewRetrier(queue, dead, nil)
+	defer retrier.Stop()
+
+	task := Task{ID: "fine", Type: CPU_INTENSIVE, MaxRetry: 3}
+	result := TaskResult{TaskID: task.ID, Status: COMPLETED}
+
+	if err := retrier.Handle(task, result); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if depth := queue.Depth(); depth != 0 {
+		t.Errorf("expected no task enqueued for a completed result, queue depth is %d", depth)
+	}
+}
@@ -0,0 +1,308 @@
+package core
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_Shutdown_WaitsForInFlightThenReturns(t *testing.T) {
+	registry := NewHandlerRegistry()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	registry.Register("slow_job", func(ctx context.Context, t *Task) (any, error) {
+		close(started)
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+
+		return "done", nil
+	})
+
+	queue := NewTaskQueue(context.Background(), 1)
+	defer queue.Close()
+	pool := NewWorkerPool(context.Background(), 1, queue, nil, nil, registry)
+	pool.Start()
+
+	task, err := NewTask("slow_job", map[string]string{})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	task.Timeout = time.Second
+
+	if err := queue.Submit(task); err != nil {
+		t.Fatalf("failed to submit task: %v", err)
+	}
+	<-started // wait until the worker is actually inside the handler
+
+	// Drain ResultChannel concurrently with Shutdown: Shutdown must not
+	// report the task done until its result is actually delivered, and
+	// the (unbuffered) ResultChannel only accepts that delivery once
+	// something is reading it.
+	results := make(chan TaskResult, 1)
+	go func() { results <- <-pool.ResultChannel }()
+
+	shutdownDone := make(chan error, 1)
+	go func() {
+		shutdownDone <- pool.Shutdown(context.Background())
+	}()
+
+	select {
+	case err := <-shutdownDone:
+		t.Fatalf("Shutdown returned before the in-flight task finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case err := <-shutdownDone:
+		if err != nil {
+			t.Errorf("expected Shutdown to return nil, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Shutdown to return")
+	}
+
+	<-results
+}
+
+func TestWorkerPool_Shutdown_DeadlineExceededReturnsCtxErr(t *testing.T) {
+	registry := NewHandlerRegistry()
+	registry.Register("never_finishes", func(ctx context.Context, t *Task) (any, error) {
+		<-ctx.Done() // task.Timeout below is long, so this blocks past the shutdown deadline
+		return nil, ctx.Err()
+	})
+
+	queue := NewTaskQueue(context.Background(), 1)
+	defer queue.Close()
+	pool := NewWorkerPool(context.Background(), 1, queue, nil, nil, registry)
+	pool.Start()
+
+	task, err := NewTask("never_finishes", map[string]string{})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	task.Timeout = time.Minute
+
+	if err := queue.Submit(task); err != nil {
+		t.Fatalf("failed to submit task: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond) // let the worker pick the task up
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := pool.Shutdown(shutdownCtx); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	pool.Cancel(context.Background(), task.ID)
+	<-pool.ResultChannel
+}
+
+func TestWorkerPool_Cancel_InterruptsInFlightTask(t *testing.T) {
+	registry := NewHandlerRegistry()
+	registry.Register("cancelable", func(ctx context.Context, t *Task) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	queue := NewTaskQueue(context.Background(), 1)
+	defer queue.Close()
+	pool := NewWorkerPool(context.Background(), 1, queue, nil, nil, registry)
+	pool.Start()
+	defer pool.Stop()
+
+	task, err := NewTask("cancelable", map[string]string{})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	task.Timeout = 5 * time.Second
+	task.ID = "cancel_me"
+
+	if err := queue.Submit(task); err != nil {
+		t.Fatalf("failed to submit task: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond) // let the worker register the task's cancel func
+
+	if err := pool.Cancel(context.Background(), task.ID); err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+
+	select {
+	case result := <-pool.ResultChannel:
+		if result.Status != CANCELED {
+			t.Errorf("expected status CANCELED, got %v", result.Status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for canceled task's result")
+	}
+}
+
+// TestWorkerPool_RequeuesTaskDequeuedDuringShutdown covers a task that
+// Start pulls off TaskChannel in the narrow window after Shutdown has
+// stopped accepting new work (but, since ctx cancellation races the
+// channel receive, before the worker's select happens to notice). It
+// drives that window deterministically by flipping the pool's
+// inFlightGate directly instead of relying on winning an actual race.
+func TestWorkerPool_RequeuesTaskDequeuedDuringShutdown(t *testing.T) {
+	registry := NewHandlerRegistry()
+	called := make(chan struct{}, 1)
+	registry.Register("requeue_test", func(ctx context.Context, t *Task) (any, error) {
+		called <- struct{}{}
+		return "ok", nil
+	})
+
+	queue := NewTaskQueue(context.Background(), 4)
+	defer queue.Close()
+	pool := NewWorkerPool(context.Background(), 1, queue, nil, nil, registry)
+	pool.Start()
+
+	// Simulate Shutdown already having stopped accepting new work, without
+	// canceling the pool's ctx, so the worker's select deterministically
+	// picks the next task off TaskChannel instead of racing ctx.Done.
+	pool.inFlight.stopAndWait()
+
+	task, err := NewTask("requeue_test", map[string]string{})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	task.ID = "race_me"
+
+	if err := queue.Submit(task); err != nil {
+		t.Fatalf("failed to submit task: %v", err)
+	}
+
+	select {
+	case <-called:
+		t.Fatal("handler ran for a task dequeued after shutdown stopped accepting work")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	select {
+	case redelivered := <-queue.GetTaskChannel():
+		if redelivered.ID != task.ID {
+			t.Errorf("expected requeued task %s, got %s", task.ID, redelivered.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the dropped task to be re-queued")
+	}
+
+	pool.Stop()
+}
+
+// fakeStatusStore is a minimal in-memory TaskStore used only to observe
+// which status transitions a WorkerPool actually records, without pulling
+// in internal/store (which imports core, not the other way around).
+type fakeStatusStore struct {
+	mu       sync.Mutex
+	statuses map[string]TaskStatus
+}
+
+func newFakeStatusStore() *fakeStatusStore {
+	return &fakeStatusStore{statuses: make(map[string]TaskStatus)}
+}
+
+func (s *fakeStatusStore) Save(ctx context.Context, task *Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[task.ID] = task.Status
+
+	return nil
+}
+
+func (s *fakeStatusStore) UpdateStatus(ctx context.Context, taskID string, status TaskStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[taskID] = status
+
+	return nil
+}
+
+func (s *fakeStatusStore) SaveResult(ctx context.Context, result *TaskResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[result.TaskID] = result.Status
+
+	return nil
+}
+
+func (s *fakeStatusStore) Get(ctx context.Context, taskID string) (*TaskRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status, ok := s.statuses[taskID]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	return &TaskRecord{Task: Task{ID: taskID, Status: status}}, nil
+}
+
+func (s *fakeStatusStore) ListByStatus(ctx context.Context, status TaskStatus) ([]*TaskRecord, error) {
+	return nil, nil
+}
+
+func (s *fakeStatusStore) Stats(ctx context.Context) (*TaskStats, error) {
+	return &TaskStats{ByStatus: map[TaskStatus]int{}, ByType: map[TaskType]int{}}, nil
+}
+
+func (s *fakeStatusStore) Cancel(ctx context.Context, taskID string) error {
+	return s.UpdateStatus(ctx, taskID, CANCELED)
+}
+
+// TestWorkerPool_Cancel_UnknownTaskID_DoesNotOverwriteStore covers a stale
+// or duplicate Cancel call for a task that isn't (or is no longer) in
+// flight: it must not touch the Store at all, since the task may have
+// already finished with a terminal status that Cancel has no business
+// overwriting.
+func TestWorkerPool_Cancel_UnknownTaskID_DoesNotOverwriteStore(t *testing.T) {
+	store := newFakeStatusStore()
+	store.statuses["done_task"] = COMPLETED
+
+	queue := NewTaskQueue(context.Background(), 1)
+	defer queue.Close()
+	pool := NewWorkerPool(context.Background(), 1, queue, store, nil, NewHandlerRegistry())
+	pool.Start()
+	defer pool.Stop()
+
+	if err := pool.Cancel(context.Background(), "done_task"); err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+
+	record, err := store.Get(context.Background(), "done_task")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if record.Task.Status != COMPLETED {
+		t.Errorf("expected Cancel on a task with no registered cancel func to leave its stored status COMPLETED, got %v", record.Task.Status)
+	}
+}
+
+func TestWorkerPool_Stop_StopsAcceptLoopGoroutines(t *testing.T) {
+	queue := NewTaskQueue(context.Background(), 4)
+	defer queue.Close()
+
+	// Baseline after the queue's own dispatch/delayed-release goroutines
+	// exist, since Stop only governs the worker pool's accept loops, not
+	// the queue's lifecycle.
+	before := runtime.NumGoroutine()
+
+	pool := NewWorkerPool(context.Background(), 4, queue, nil, nil, DefaultHandlerRegistry())
+	pool.Start()
+
+	pool.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected worker goroutines to exit after Stop, goroutine count before=%d after=%d", before, after)
+	}
+}
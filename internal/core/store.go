@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTaskNotFound is returned by TaskStore implementations when no record
+// exists for the requested task ID.
+var ErrTaskNotFound = errors.New("core: task not found")
+
+// TaskRecord is a TaskStore's view of a single task: the task itself, its
+// most recent result (if it has completed at least once), and when the
+// record was last touched.
+type TaskRecord struct {
+	Task      Task
+	Result    *TaskResult
+	UpdatedAt time.Time
+}
+
+// TaskStats summarizes tracked tasks grouped by status and by type, as
+// returned by TaskStore.Stats.
+type TaskStats struct {
+	ByStatus map[TaskStatus]int
+	ByType   map[TaskType]int
+}
+
+// TaskStore persists Task and TaskResult state as tasks transition through
+// a TaskQueue/WorkerPool, so operators can inspect and manage in-flight
+// work rather than only firing tasks blindly through a channel.
+//
+// Implementations live in internal/store (in-memory, Redis, ...) and are
+// wired into a WorkerPool at construction time.
+type TaskStore interface {
+	// Save upserts a task's record, creating it on first sight.
+	Save(ctx context.Context, task *Task) error
+
+	// UpdateStatus records a status transition for taskID.
+	UpdateStatus(ctx context.Context, taskID string, status TaskStatus) error
+
+	// SaveResult records the final TaskResult for taskID and updates its
+	// status to match the result's status.
+	SaveResult(ctx context.Context, result *TaskResult) error
+
+	// Get returns the current record for taskID, or ErrTaskNotFound.
+	Get(ctx context.Context, taskID string) (*TaskRecord, error)
+
+	// ListByStatus returns all records currently in the given status.
+	ListByStatus(ctx context.Context, status TaskStatus) ([]*TaskRecord, error)
+
+	// Stats returns counts of tracked tasks grouped by status and by type.
+	Stats(ctx context.Context) (*TaskStats, error)
+
+	// Cancel marks taskID as CANCELED. It only records the request;
+	// interrupting in-flight processing is the caller's responsibility.
+	Cancel(ctx context.Context, taskID string) error
+}
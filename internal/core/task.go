@@ -23,6 +23,7 @@ const (
 	COMPLETED  TaskStatus = "completed"
 	FAILED     TaskStatus = "failed"
 	TIMEOUT    TaskStatus = "timeout"
+	CANCELED   TaskStatus = "canceled"
 )
 
 type Task struct {
@@ -33,6 +34,14 @@ type Task struct {
 	CreatedAt time.Time       `json:"created_at"`
 	Timeout   time.Duration   `json:"timeout"`
 	Status    TaskStatus      `json:"status"`
+	MaxRetry  int             `json:"max_retry"`
+	Retried   int             `json:"retried"`
+	LastError string          `json:"last_error,omitempty"`
+
+	// RetryDelay overrides the Retrier's default backoff function for this
+	// task. It is excluded from JSON, so a JSON-backed TaskStore or Queue
+	// (RedisStore, RedisQueue) drops it; MemoryStore keeps it.
+	RetryDelay RetryDelayFunc `json:"-"`
 }
 
 type TaskResult struct {
@@ -43,9 +52,33 @@ type TaskResult struct {
 	WorkerID    string          `json:"worker_id"`
 	CompletedAt time.Time       `json:"completed_at"`
 	Error       string          `json:"error,omitempty"`
+	Metrics     *Metrics        `json:"metrics,omitempty"`
 }
 
-func NewTask(taskType TaskType, payload interface{}) (*Task, error) {
+// defaultMaxRetry is the number of retry attempts a task gets before the
+// Retrier moves it to the dead queue, unless overridden by WithMaxRetry.
+const defaultMaxRetry = 3
+
+// TaskOption customizes a Task at construction time. See NewTask.
+type TaskOption func(*Task)
+
+// WithMaxRetry overrides the default number of retry attempts allowed
+// before a failed or timed-out task is moved to the dead queue.
+func WithMaxRetry(maxRetry int) TaskOption {
+	return func(t *Task) {
+		t.MaxRetry = maxRetry
+	}
+}
+
+// WithRetryDelay overrides the backoff function a Retrier uses to
+// schedule this task's retries, in place of its default.
+func WithRetryDelay(fn RetryDelayFunc) TaskOption {
+	return func(t *Task) {
+		t.RetryDelay = fn
+	}
+}
+
+func NewTask(taskType TaskType, payload interface{}, opts ...TaskOption) (*Task, error) {
 	id := fmt.Sprintf("task_%d_%d", time.Now().Unix(), time.Now().Nanosecond())
 
 	p, err := json.Marshal(payload)
@@ -67,7 +100,11 @@ func NewTask(taskType TaskType, payload interface{}) (*Task, error) {
 
 	}
 
-	task := Task{ID: id, Type: taskType, CreatedAt: time.Now(), Payload: raw, Timeout: timeout, Status: PENDING}
+	task := Task{ID: id, Type: taskType, CreatedAt: time.Now(), Payload: raw, Timeout: timeout, Status: PENDING, MaxRetry: defaultMaxRetry}
+
+	for _, opt := range opts {
+		opt(&task)
+	}
 
 	if err := task.IsValid(); err != nil {
 		return nil, fmt.Errorf("invalid task created: %w", err)
@@ -124,18 +161,18 @@ func (r *TaskResult) String() string {
 	return s
 }
 
+// IsValid reports whether tt is non-empty. TaskType is no longer a closed
+// enum: callers register handlers for their own TaskType values via a
+// HandlerRegistry, and Worker.invokeHandler fails a task at dispatch time
+// if no handler is registered for its type, rather than this method
+// rejecting unrecognized types up front.
 func (tt TaskType) IsValid() bool {
-	switch tt {
-	case CPU_INTENSIVE, IO_BOUND, TIME_BASED:
-		return true
-	default:
-		return false
-	}
+	return tt != ""
 }
 
 func (ts TaskStatus) IsValid() bool {
 	switch ts {
-	case PENDING, PROCESSING, COMPLETED, FAILED, TIMEOUT:
+	case PENDING, PROCESSING, COMPLETED, FAILED, TIMEOUT, CANCELED:
 		return true
 	default:
 		return false
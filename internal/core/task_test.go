@@ -50,8 +50,8 @@ func TestNewTask_InvalidInputs(t *testing.T) {
 		expectedErr bool
 	}{
 		{
-			name:     "Test 1: Unexpected Task Type, invalid task type error expected",
-			taskType: "gpu_bound",
+			name:     "Test 1: Empty Task Type, invalid task type error expected",
+			taskType: "",
 			payload:  map[string]interface{}{"name": "Marine", "race": "Terran", "health": 45, "damage": 6, "is_upgraded": true},
 		},
 		{
@@ -88,8 +88,8 @@ func TestTask_IsValid(t *testing.T) {
 			expectedErr: true,
 		},
 		{
-			name:        "Test 3: Invalid Task - Invalid Type",
-			task:        Task{ID: "valid_id", Type: "gpu_intensive", CreatedAt: time.Now(), Timeout: 20 * time.Second, Status: "pending"},
+			name:        "Test 3: Invalid Task - Empty Type",
+			task:        Task{ID: "valid_id", Type: "", CreatedAt: time.Now(), Timeout: 20 * time.Second, Status: "pending"},
 			expectedErr: true,
 		},
 		{
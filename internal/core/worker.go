@@ -1,132 +1,397 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 )
 
+// heartbeatInterval is how often a Worker pings its Queue while a task is
+// in flight, when that Queue implements Heartbeater.
+const heartbeatInterval = 10 * time.Second
+
 type Worker struct {
 	ID            string
 	TaskChannel   <-chan Task
 	ResultChannel chan<- TaskResult
-	QuitChannel   <-chan bool
+	Store         TaskStore
+	Retrier       *Retrier
+	Registry      *HandlerRegistry
+	Queue         Queue
+
+	inFlight *inFlightGate
+	cancels  *sync.Map // taskID -> context.CancelFunc, for in-flight tasks
 }
 
 type WorkerPool struct {
 	Workers       []*Worker
+	Queue         Queue
 	TaskChannel   <-chan Task
-	ResultChannel chan<- TaskResult
-	QuitChannel   chan<- bool
+	ResultChannel chan TaskResult
 	Size          int
+	Store         TaskStore
+	Retrier       *Retrier
+	Registry      *HandlerRegistry
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	inFlight inFlightGate
+	cancels  *sync.Map
+}
+
+// inFlightGate tracks in-flight tasks like a sync.WaitGroup, but also lets
+// Shutdown atomically stop new tasks from joining the group. Without this,
+// a worker's Add and Shutdown's Wait could race: canceling ctx does not
+// deterministically stop a worker's accept-loop select from still picking
+// a fresh task off TaskChannel, so Add could be called concurrently with
+// (or after) a Wait that already observed a zero counter.
+type inFlightGate struct {
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	stopped bool
 }
 
-func NewWorker(id string, taskChan <-chan Task, resultChan chan<- TaskResult, quitChan <-chan bool) *Worker {
-	return &Worker{ID: id, TaskChannel: taskChan, ResultChannel: resultChan, QuitChannel: quitChan}
+// tryJoin reports whether the caller may start tracked work. It returns
+// false once stopAndWait has been called, in which case no Add is made.
+func (g *inFlightGate) tryJoin() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.stopped {
+		return false
+	}
+
+	g.wg.Add(1)
+	return true
 }
 
-func (w *Worker) Start() {
+// leave marks a tryJoin'd unit of work as finished.
+func (g *inFlightGate) leave() {
+	g.wg.Done()
+}
+
+// stopAndWait stops any further tryJoin calls from succeeding, then blocks
+// until every already-joined unit of work calls leave.
+func (g *inFlightGate) stopAndWait() {
+	g.mu.Lock()
+	g.stopped = true
+	g.mu.Unlock()
+
+	g.wg.Wait()
+}
+
+func NewWorker(id string, taskChan <-chan Task, resultChan chan<- TaskResult, store TaskStore, retrier *Retrier, registry *HandlerRegistry, queue Queue, inFlight *inFlightGate, cancels *sync.Map) *Worker {
+	if registry == nil {
+		registry = DefaultHandlerRegistry()
+	}
+
+	return &Worker{
+		ID:            id,
+		TaskChannel:   taskChan,
+		ResultChannel: resultChan,
+		Store:         store,
+		Retrier:       retrier,
+		Registry:      registry,
+		Queue:         queue,
+		inFlight:      inFlight,
+		cancels:       cancels,
+	}
+}
+
+// Start runs the worker's accept loop until ctx is done. Cancelling ctx
+// only stops the worker from picking up new tasks; a task already being
+// processed keeps running under its own timeout-bound context (see
+// invokeHandler) until it finishes, times out, or is individually
+// canceled via WorkerPool.Cancel.
+func (w *Worker) Start(ctx context.Context) {
 	go func() {
 		for {
 			select {
 			case task := <-w.TaskChannel:
+				if w.inFlight != nil && !w.inFlight.tryJoin() {
+					// Shutdown has already stopped accepting work and may
+					// be waiting on a zero in-flight count; processing this
+					// task now would either race that wait or make
+					// Shutdown return before the task is done. Hand it
+					// back rather than silently losing it.
+					w.requeueOrMarkTimeout(task)
+					return
+				}
+
 				result := w.processTask(task)
+
 				w.ResultChannel <- result
-			case <-w.QuitChannel:
+
+				if w.inFlight != nil {
+					w.inFlight.leave()
+				}
+			case <-ctx.Done():
 				return
 			}
 		}
 	}()
 }
 
+// requeueOrMarkTimeout handles a task Start dequeued just as Shutdown
+// stopped this worker from accepting new work. It was never handed to
+// processTask, so it must not simply vanish: re-submit it so it's picked
+// up again (by this queue's next worker, or after a restart), falling
+// back to recording it as TIMEOUT in the Store if the queue won't take
+// it back.
+func (w *Worker) requeueOrMarkTimeout(task Task) {
+	if err := w.Queue.Submit(&task); err == nil {
+		return
+	} else {
+		log.Printf("worker %s: failed to re-queue task %s during shutdown, marking TIMEOUT: %v", w.ID, task.ID, err)
+	}
+
+	if w.Store == nil {
+		return
+	}
+
+	ctx := context.Background()
+	if err := w.Store.Save(ctx, &task); err != nil {
+		log.Printf("worker %s: failed to save dropped task %s: %v", w.ID, task.ID, err)
+	}
+
+	result := TaskResult{TaskID: task.ID, Status: TIMEOUT, WorkerID: w.ID, CompletedAt: time.Now(), Error: "task dropped during shutdown before processing"}
+	if err := w.Store.SaveResult(ctx, &result); err != nil {
+		log.Printf("worker %s: failed to record shutdown-drop result for task %s: %v", w.ID, task.ID, err)
+	}
+}
+
 func (w *Worker) processTask(task Task) TaskResult {
 	startTime := time.Now()
 
-	switch task.Type {
-	case CPU_INTENSIVE:
-		return w.handleCPUTask(task, startTime)
-	case IO_BOUND:
-		return w.handleIOTask(task, startTime)
-	case TIME_BASED:
-		return w.handleTimeBasedTask(task, startTime)
-	default:
-		return TaskResult{TaskID: task.ID, Status: FAILED, Data: task.Payload, Duration: time.Since(startTime), WorkerID: w.ID, CompletedAt: time.Now(), Error: "unknown task type"}
+	if w.Store != nil {
+		ctx := context.Background()
+		if err := w.Store.Save(ctx, &task); err != nil {
+			log.Printf("worker %s: failed to save task %s: %v", w.ID, task.ID, err)
+		}
+		if err := w.Store.UpdateStatus(ctx, task.ID, PROCESSING); err != nil {
+			log.Printf("worker %s: failed to record processing status for task %s: %v", w.ID, task.ID, err)
+		}
+	}
+
+	if hb, ok := w.Queue.(Heartbeater); ok {
+		stop := make(chan struct{})
+		defer close(stop)
+		go w.heartbeat(hb, task.ID, stop)
+	}
+
+	result := w.invokeHandler(task, startTime)
+
+	if acker, ok := w.Queue.(Acker); ok {
+		if err := acker.Ack(task.ID); err != nil {
+			log.Printf("worker %s: failed to ack task %s: %v", w.ID, task.ID, err)
+		}
+	}
+
+	if w.Store != nil {
+		if err := w.Store.SaveResult(context.Background(), &result); err != nil {
+			log.Printf("worker %s: failed to save result for task %s: %v", w.ID, task.ID, err)
+		}
+	}
+
+	if w.Retrier != nil {
+		if err := w.Retrier.Handle(task, result); err != nil {
+			log.Printf("worker %s: failed to handle retry for task %s: %v", w.ID, task.ID, err)
+		}
+	}
+
+	return result
+}
+
+// heartbeat periodically pings hb on behalf of taskID until stop is
+// closed, so a Queue that tracks worker liveness (like RedisQueue) can
+// tell this task is still being actively processed.
+func (w *Worker) heartbeat(hb Heartbeater, taskID string, stop <-chan struct{}) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
 
+	for {
+		select {
+		case <-ticker.C:
+			if err := hb.Heartbeat(taskID); err != nil {
+				log.Printf("worker %s: failed to heartbeat task %s: %v", w.ID, taskID, err)
+			}
+		case <-stop:
+			return
+		}
 	}
 }
 
-func NewWorkerPool(size int, taskChan <-chan Task) *WorkerPool {
-	resultChan := make(chan TaskResult)
-	quitChan := make(chan bool)
-	workerPool := WorkerPool{
+// invokeHandler looks up task.Type in w.Registry and runs it with a
+// context bounded by task.Timeout. While the handler runs, its cancel
+// func is registered under task.ID so WorkerPool.Cancel can interrupt it
+// directly. A deadline exceeded error becomes a TIMEOUT result; an
+// explicit cancellation becomes a CANCELED result.
+func (w *Worker) invokeHandler(task Task, startTime time.Time) TaskResult {
+	handler, ok := w.Registry.Get(task.Type)
+	if !ok {
+		return TaskResult{TaskID: task.ID, Status: FAILED, WorkerID: w.ID, CompletedAt: time.Now(), Duration: time.Since(startTime), Error: fmt.Sprintf("no handler registered for task type %q", task.Type)}
+	}
+
+	ctx, cancel := context.WithTimeout(withWorkerID(context.Background(), w.ID), task.Timeout)
+	defer cancel()
+
+	if w.cancels != nil {
+		w.cancels.Store(task.ID, cancel)
+		defer w.cancels.Delete(task.ID)
+	}
+
+	result, err := handler(ctx, &task)
+
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return TaskResult{TaskID: task.ID, Status: TIMEOUT, WorkerID: w.ID, CompletedAt: time.Now(), Duration: time.Since(startTime), Error: "task exceeded its timeout"}
+	case context.Canceled:
+		return TaskResult{TaskID: task.ID, Status: CANCELED, WorkerID: w.ID, CompletedAt: time.Now(), Duration: time.Since(startTime), Error: "task was canceled"}
+	}
+
+	if err != nil {
+		return TaskResult{TaskID: task.ID, Status: FAILED, WorkerID: w.ID, CompletedAt: time.Now(), Duration: time.Since(startTime), Error: err.Error()}
+	}
+
+	taskResult := TaskResult{TaskID: task.ID, Status: COMPLETED, WorkerID: w.ID, CompletedAt: time.Now(), Duration: time.Since(startTime)}
+
+	if mr, ok := result.(MetricsResult); ok {
+		metrics := mr.Metrics
+		taskResult.Metrics = &metrics
+		result = mr.Data
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return TaskResult{TaskID: task.ID, Status: FAILED, WorkerID: w.ID, CompletedAt: time.Now(), Duration: time.Since(startTime), Error: err.Error()}
+	}
+
+	taskResult.Data = raw
+
+	return taskResult
+}
+
+// NewWorkerPool builds a pool of size workers reading from queue's task
+// channel. queue may be any Queue implementation, such as the in-process
+// TaskQueue or a RedisQueue shared across multiple TaskEngine processes.
+// ctx governs the pool's lifetime: canceling it (or calling Stop/Shutdown)
+// stops workers from accepting new tasks. A nil ctx behaves as
+// context.Background().
+func NewWorkerPool(ctx context.Context, size int, queue Queue, taskStore TaskStore, retrier *Retrier, registry *HandlerRegistry) *WorkerPool {
+	if registry == nil {
+		registry = DefaultHandlerRegistry()
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	poolCtx, cancel := context.WithCancel(ctx)
+
+	taskChan := queue.GetTaskChannel()
+
+	workerPool := &WorkerPool{
+		Queue:         queue,
 		TaskChannel:   taskChan,
-		ResultChannel: resultChan,
-		QuitChannel:   quitChan,
+		ResultChannel: make(chan TaskResult),
 		Size:          size,
+		Store:         taskStore,
+		Retrier:       retrier,
+		Registry:      registry,
+		ctx:           poolCtx,
+		cancel:        cancel,
+		cancels:       &sync.Map{},
 	}
 
 	for i := 1; i <= size; i++ {
 		workerID := fmt.Sprintf("worker_%d", i)
-		worker := NewWorker(workerID, taskChan, resultChan, quitChan)
+		worker := NewWorker(workerID, taskChan, workerPool.ResultChannel, taskStore, retrier, registry, queue, &workerPool.inFlight, workerPool.cancels)
 		workerPool.Workers = append(workerPool.Workers, worker)
 	}
 
-	return &workerPool
+	return workerPool
 }
 
 func (wp *WorkerPool) Start() {
 	for _, worker := range wp.Workers {
-		worker.Start()
+		worker.Start(wp.ctx)
 	}
 }
 
+// Stop cancels the pool's context immediately, so workers stop accepting
+// new tasks without waiting for in-flight work to finish. Prefer
+// Shutdown for a graceful stop.
 func (wp *WorkerPool) Stop() {
-	close(wp.QuitChannel)
+	wp.cancel()
 }
 
-// BELOW THIS POINT, PLACEHOLDER FUNCTIONS
-// TODO: MAKE REAL FUNCTIONS WHEN TIME IS RIGHT
-func (w *Worker) handleCPUTask(task Task, startTime time.Time) TaskResult {
-	// Simulate CPU work with a brief calculation
-	result := 0
-	for i := 0; i < 1000000; i++ {
-		result += i // Just enough work to see CPU usage
+// Shutdown stops the pool from accepting new tasks and waits for
+// in-flight tasks to finish, returning nil once they do, or ctx's error
+// if ctx is done first.
+func (wp *WorkerPool) Shutdown(ctx context.Context) error {
+	wp.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		wp.inFlight.stopAndWait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	return TaskResult{
-		TaskID:      task.ID,
-		Status:      COMPLETED,
-		WorkerID:    w.ID,
-		CompletedAt: time.Now(),
-		Duration:    time.Since(startTime),
-		Data:        json.RawMessage(fmt.Sprintf(`{"result": %d}`, result)),
+// ListByStatus returns every tracked task currently in the given status.
+// It requires a Store to have been configured on the pool.
+func (wp *WorkerPool) ListByStatus(ctx context.Context, status TaskStatus) ([]*TaskRecord, error) {
+	if wp.Store == nil {
+		return nil, fmt.Errorf("worker pool has no TaskStore configured")
 	}
+
+	return wp.Store.ListByStatus(ctx, status)
 }
 
-func (w *Worker) handleIOTask(task Task, startTime time.Time) TaskResult {
-	// Simulate I/O delay
-	time.Sleep(100 * time.Millisecond) // Pretend we're reading a file
+// Get returns the tracked record for taskID.
+func (wp *WorkerPool) Get(ctx context.Context, taskID string) (*TaskRecord, error) {
+	if wp.Store == nil {
+		return nil, fmt.Errorf("worker pool has no TaskStore configured")
+	}
 
-	return TaskResult{
-		TaskID:      task.ID,
-		Status:      COMPLETED,
-		WorkerID:    w.ID,
-		CompletedAt: time.Now(),
-		Duration:    time.Since(startTime),
-		Data:        json.RawMessage(`{"message": "I/O operation completed"}`),
+	return wp.Store.Get(ctx, taskID)
+}
+
+// Stats returns counts of tracked tasks grouped by status and by type.
+func (wp *WorkerPool) Stats(ctx context.Context) (*TaskStats, error) {
+	if wp.Store == nil {
+		return nil, fmt.Errorf("worker pool has no TaskStore configured")
 	}
+
+	return wp.Store.Stats(ctx)
 }
 
-func (w *Worker) handleTimeBasedTask(task Task, startTime time.Time) TaskResult {
-	// Simulate time-based processing
-	time.Sleep(500 * time.Millisecond) // Pretend we're processing something
+// Cancel interrupts taskID if a worker is currently processing it, by
+// canceling its handler's context, and records the cancellation in the
+// Store if one is configured. If taskID isn't currently in flight
+// (already finished, never submitted, or a stale/duplicate call), Cancel
+// does nothing: recording a cancellation for it would silently overwrite
+// whatever terminal status the task actually finished with.
+func (wp *WorkerPool) Cancel(ctx context.Context, taskID string) error {
+	cancelFunc, ok := wp.cancels.Load(taskID)
+	if !ok {
+		return nil
+	}
 
-	return TaskResult{
-		TaskID:      task.ID,
-		Status:      COMPLETED,
-		WorkerID:    w.ID,
-		CompletedAt: time.Now(),
-		Duration:    time.Since(startTime),
-		Data:        json.RawMessage(`{"status": "time-based processing done"}`),
+	cancelFunc.(context.CancelFunc)()
+
+	if wp.Store == nil {
+		return nil
 	}
+
+	return wp.Store.Cancel(ctx, taskID)
 }
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func newTestWorker(registry *HandlerRegistry) *Worker {
+	return NewWorker("worker_test", nil, nil, nil, nil, registry, nil, nil, nil)
+}
+
+func TestWorker_InvokeHandler_CustomTaskType(t *testing.T) {
+	registry := NewHandlerRegistry()
+	registry.Register("greeting", func(ctx context.Context, t *Task) (any, error) {
+		return map[string]string{"greeting": "hello"}, nil
+	})
+
+	w := newTestWorker(registry)
+
+	task, err := NewTask("greeting", map[string]string{})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+
+	result := w.invokeHandler(*task, time.Now())
+
+	if result.Status != COMPLETED {
+		t.Fatalf("expected status COMPLETED, got %v (error: %s)", result.Status, result.Error)
+	}
+
+	var data map[string]string
+	if err := json.Unmarshal(result.Data, &data); err != nil {
+		t.Fatalf("failed to unmarshal result data: %v", err)
+	}
+	if data["greeting"] != "hello" {
+		t.Errorf("expected greeting 'hello', got %v", data)
+	}
+}
+
+func TestWorker_InvokeHandler_NoHandlerRegistered(t *testing.T) {
+	w := newTestWorker(NewHandlerRegistry())
+
+	task, err := NewTask("unregistered_type", map[string]string{})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+
+	result := w.invokeHandler(*task, time.Now())
+
+	if result.Status != FAILED {
+		t.Errorf("expected status FAILED, got %v", result.Status)
+	}
+}
+
+func TestWorker_InvokeHandler_TimesOut(t *testing.T) {
+	registry := NewHandlerRegistry()
+	registry.Register("slow", func(ctx context.Context, t *Task) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	w := newTestWorker(registry)
+
+	task, err := NewTask("slow", map[string]string{})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	task.Timeout = 10 * time.Millisecond
+
+	result := w.invokeHandler(*task, time.Now())
+
+	if result.Status != TIMEOUT {
+		t.Errorf("expected status TIMEOUT, got %v", result.Status)
+	}
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AdonaIsium/TaskEngine/internal/core"
+)
+
+// MemoryStore is an in-process core.TaskStore backed by a map, suitable
+// for single-process deployments and tests.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	records map[string]*core.TaskRecord
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*core.TaskRecord)}
+}
+
+func (s *MemoryStore) Save(ctx context.Context, task *core.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[task.ID] = &core.TaskRecord{Task: *task, UpdatedAt: time.Now()}
+
+	return nil
+}
+
+func (s *MemoryStore) UpdateStatus(ctx context.Context, taskID string, status core.TaskStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.records[taskID]
+	if !ok {
+		return core.ErrTaskNotFound
+	}
+
+	rec.Task.Status = status
+	rec.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (s *MemoryStore) SaveResult(ctx context.Context, result *core.TaskResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.records[result.TaskID]
+	if !ok {
+		return core.ErrTaskNotFound
+	}
+
+	r := *result
+	rec.Result = &r
+	rec.Task.Status = result.Status
+	rec.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, taskID string) (*core.TaskRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rec, ok := s.records[taskID]
+	if !ok {
+		return nil, core.ErrTaskNotFound
+	}
+
+	cp := *rec
+
+	return &cp, nil
+}
+
+func (s *MemoryStore) ListByStatus(ctx context.Context, status core.TaskStatus) ([]*core.TaskRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*core.TaskRecord
+	for _, rec := range s.records {
+		if rec.Task.Status == status {
+			cp := *rec
+			out = append(out, &cp)
+		}
+	}
+
+	return out, nil
+}
+
+func (s *MemoryStore) Stats(ctx context.Context) (*core.TaskStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := &core.TaskStats{ByStatus: make(map[core.TaskStatus]int), ByType: make(map[core.TaskType]int)}
+	for _, rec := range s.records {
+		stats.ByStatus[rec.Task.Status]++
+		stats.ByType[rec.Task.Type]++
+	}
+
+	return stats, nil
+}
+
+func (s *MemoryStore) Cancel(ctx context.Context, taskID string) error {
+	return s.UpdateStatus(ctx, taskID, core.CANCELED)
+}
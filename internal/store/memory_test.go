@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AdonaIsium/TaskEngine/internal/core"
+)
+
+func newTestTask(t *testing.T, id string, taskType core.TaskType) *core.Task {
+	t.Helper()
+
+	task, err := core.NewTask(taskType, map[string]string{"id": id})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+
+	task.ID = id
+
+	return task
+}
+
+func TestMemoryStore_SaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	task := newTestTask(t, "task_1", core.CPU_INTENSIVE)
+
+	if err := s.Save(ctx, task); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	rec, err := s.Get(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if rec.Task.ID != task.ID {
+		t.Errorf("expected task ID %s, got %s", task.ID, rec.Task.ID)
+	}
+}
+
+func TestMemoryStore_Get_NotFound(t *testing.T) {
+	s := NewMemoryStore()
+
+	_, err := s.Get(context.Background(), "does_not_exist")
+	if err != core.ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestMemoryStore_UpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	task := newTestTask(t, "task_2", core.IO_BOUND)
+	if err := s.Save(ctx, task); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if err := s.UpdateStatus(ctx, task.ID, core.PROCESSING); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+
+	rec, err := s.Get(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if rec.Task.Status != core.PROCESSING {
+		t.Errorf("expected status PROCESSING, got %v", rec.Task.Status)
+	}
+}
+
+func TestMemoryStore_ListByStatus(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	pending := newTestTask(t, "task_pending", core.CPU_INTENSIVE)
+	processing := newTestTask(t, "task_processing", core.CPU_INTENSIVE)
+
+	if err := s.Save(ctx, pending); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := s.Save(ctx, processing); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := s.UpdateStatus(ctx, processing.ID, core.PROCESSING); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+
+	results, err := s.ListByStatus(ctx, core.PENDING)
+	if err != nil {
+		t.Fatalf("ListByStatus returned error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Task.ID != pending.ID {
+		t.Errorf("expected only %s in PENDING, got %+v", pending.ID, results)
+	}
+}
+
+func TestMemoryStore_Stats(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	if err := s.Save(ctx, newTestTask(t, "task_a", core.CPU_INTENSIVE)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := s.Save(ctx, newTestTask(t, "task_b", core.IO_BOUND)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stats, err := s.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats returned error: %v", err)
+	}
+
+	if stats.ByStatus[core.PENDING] != 2 {
+		t.Errorf("expected 2 PENDING tasks, got %d", stats.ByStatus[core.PENDING])
+	}
+	if stats.ByType[core.CPU_INTENSIVE] != 1 || stats.ByType[core.IO_BOUND] != 1 {
+		t.Errorf("unexpected type breakdown: %+v", stats.ByType)
+	}
+}
+
+func TestMemoryStore_Cancel(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	task := newTestTask(t, "task_cancel", core.TIME_BASED)
+	if err := s.Save(ctx, task); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if err := s.Cancel(ctx, task.ID); err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+
+	rec, err := s.Get(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if rec.Task.Status != core.CANCELED {
+		t.Errorf("expected status CANCELED, got %v", rec.Task.Status)
+	}
+}
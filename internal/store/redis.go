@@ -0,0 +1,157 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/AdonaIsium/TaskEngine/internal/core"
+)
+
+// trackedStatuses lists every status RedisStore indexes into a per-status
+// set, so ListByStatus and Stats never need to scan the keyspace.
+var trackedStatuses = []core.TaskStatus{
+	core.PENDING, core.PROCESSING, core.COMPLETED, core.FAILED, core.TIMEOUT, core.CANCELED,
+}
+
+// RedisStore is a core.TaskStore backed by Redis, suitable for sharing
+// task state across multiple TaskEngine processes. Each record is stored
+// as a JSON blob under taskKey(id); statusKey(status) sets track which
+// task IDs currently hold each status.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "taskengine"
+	}
+
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) taskKey(id string) string {
+	return fmt.Sprintf("%s:task:%s", s.prefix, id)
+}
+
+func (s *RedisStore) statusKey(status core.TaskStatus) string {
+	return fmt.Sprintf("%s:status:%s", s.prefix, status)
+}
+
+func (s *RedisStore) Save(ctx context.Context, task *core.Task) error {
+	return s.writeRecord(ctx, &core.TaskRecord{Task: *task, UpdatedAt: time.Now()})
+}
+
+func (s *RedisStore) UpdateStatus(ctx context.Context, taskID string, status core.TaskStatus) error {
+	rec, err := s.Get(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	rec.Task.Status = status
+	rec.UpdatedAt = time.Now()
+
+	return s.writeRecord(ctx, rec)
+}
+
+func (s *RedisStore) SaveResult(ctx context.Context, result *core.TaskResult) error {
+	rec, err := s.Get(ctx, result.TaskID)
+	if err != nil {
+		return err
+	}
+
+	r := *result
+	rec.Result = &r
+	rec.Task.Status = result.Status
+	rec.UpdatedAt = time.Now()
+
+	return s.writeRecord(ctx, rec)
+}
+
+func (s *RedisStore) Get(ctx context.Context, taskID string) (*core.TaskRecord, error) {
+	raw, err := s.client.Get(ctx, s.taskKey(taskID)).Bytes()
+	if err == redis.Nil {
+		return nil, core.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rec core.TaskRecord
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return nil, err
+	}
+
+	return &rec, nil
+}
+
+func (s *RedisStore) ListByStatus(ctx context.Context, status core.TaskStatus) ([]*core.TaskRecord, error) {
+	ids, err := s.client.SMembers(ctx, s.statusKey(status)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*core.TaskRecord, 0, len(ids))
+	for _, id := range ids {
+		rec, err := s.Get(ctx, id)
+		if err == core.ErrTaskNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, rec)
+	}
+
+	return out, nil
+}
+
+func (s *RedisStore) Stats(ctx context.Context) (*core.TaskStats, error) {
+	stats := &core.TaskStats{ByStatus: make(map[core.TaskStatus]int), ByType: make(map[core.TaskType]int)}
+
+	for _, status := range trackedStatuses {
+		records, err := s.ListByStatus(ctx, status)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.ByStatus[status] = len(records)
+		for _, rec := range records {
+			stats.ByType[rec.Task.Type]++
+		}
+	}
+
+	return stats, nil
+}
+
+func (s *RedisStore) Cancel(ctx context.Context, taskID string) error {
+	return s.UpdateStatus(ctx, taskID, core.CANCELED)
+}
+
+// writeRecord persists rec and moves its task ID into the status set
+// matching its current status, removing it from every other tracked set.
+func (s *RedisStore) writeRecord(ctx context.Context, rec *core.TaskRecord) error {
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, s.taskKey(rec.Task.ID), raw, 0)
+	for _, status := range trackedStatuses {
+		if status == rec.Task.Status {
+			pipe.SAdd(ctx, s.statusKey(status), rec.Task.ID)
+		} else {
+			pipe.SRem(ctx, s.statusKey(status), rec.Task.ID)
+		}
+	}
+
+	_, err = pipe.Exec(ctx)
+
+	return err
+}
@@ -0,0 +1,295 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/AdonaIsium/TaskEngine/internal/core"
+)
+
+// reclaimScript atomically moves a task off a processing list back onto
+// its pending list once its heartbeat key has expired, so a task whose
+// worker crashed or was partitioned away isn't stranded forever.
+var reclaimScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[2]) == 0 then
+	local removed = redis.call("LREM", KEYS[1], 0, ARGV[1])
+	if removed > 0 then
+		redis.call("LPUSH", KEYS[3], ARGV[1])
+	end
+	return removed
+end
+return 0
+`)
+
+// RedisQueue is a core.Queue backed by Redis lists, letting multiple
+// TaskEngine processes share the same pending work. Submit LPUSHes a
+// task's ID onto its priority band's pending list; dispatch BRPOPLPUSHes
+// IDs off the due band into this instance's processing list so an
+// in-flight task survives a dispatcher restart, and a recovery goroutine
+// reclaims tasks whose worker has stopped heartbeating.
+type RedisQueue struct {
+	client *redis.Client
+	prefix string
+	id     string // unique per RedisQueue instance; names this instance's processing list
+
+	heartbeatTTL    time.Duration
+	reclaimInterval time.Duration
+
+	out       chan core.Task
+	quit      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewRedisQueue starts a RedisQueue's dispatch and orphan-recovery
+// goroutines. consumerID must be unique across every RedisQueue instance
+// sharing prefix, since it names this instance's processing list. A task
+// is reclaimed back onto its pending list once heartbeatTTL elapses
+// without a Heartbeat call; reclaimInterval controls how often the
+// recovery goroutine checks for that. Zero values fall back to 30s and
+// 10s respectively.
+func NewRedisQueue(client *redis.Client, prefix, consumerID string, heartbeatTTL, reclaimInterval time.Duration) *RedisQueue {
+	if prefix == "" {
+		prefix = "taskengine"
+	}
+	if heartbeatTTL <= 0 {
+		heartbeatTTL = 30 * time.Second
+	}
+	if reclaimInterval <= 0 {
+		reclaimInterval = 10 * time.Second
+	}
+
+	q := &RedisQueue{
+		client:          client,
+		prefix:          prefix,
+		id:              consumerID,
+		heartbeatTTL:    heartbeatTTL,
+		reclaimInterval: reclaimInterval,
+		out:             make(chan core.Task),
+		quit:            make(chan struct{}),
+	}
+
+	go q.dispatch()
+	go q.reclaim()
+
+	return q
+}
+
+func (q *RedisQueue) pendingKey(band int) string {
+	return fmt.Sprintf("%s:queue:pending:%d", q.prefix, band)
+}
+
+func (q *RedisQueue) processingKey() string {
+	return fmt.Sprintf("%s:queue:processing:%s", q.prefix, q.id)
+}
+
+func (q *RedisQueue) taskKey(taskID string) string {
+	return fmt.Sprintf("%s:queue:task:%s", q.prefix, taskID)
+}
+
+func (q *RedisQueue) heartbeatKey(taskID string) string {
+	return fmt.Sprintf("%s:queue:heartbeat:%s", q.prefix, taskID)
+}
+
+// Submit persists task and LPUSHes its ID onto the pending list matching
+// task.Priority.
+func (q *RedisQueue) Submit(task *core.Task) error {
+	ctx := context.Background()
+
+	raw, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	if err := q.client.Set(ctx, q.taskKey(task.ID), raw, 0).Err(); err != nil {
+		return err
+	}
+
+	band := core.PriorityBand(task.Priority)
+
+	return q.client.LPush(ctx, q.pendingKey(band), task.ID).Err()
+}
+
+func (q *RedisQueue) GetTaskChannel() <-chan core.Task {
+	return q.out
+}
+
+// Close stops the dispatch and recovery goroutines and closes the
+// channel returned by GetTaskChannel. It is safe to call more than once.
+func (q *RedisQueue) Close() {
+	q.closeOnce.Do(func() { close(q.quit) })
+}
+
+// Status reports each priority band's pending depth. Capacity is always
+// 0: unlike TaskQueue's buffered channels, a Redis list has no fixed
+// capacity.
+func (q *RedisQueue) Status() []core.BandStatus {
+	ctx := context.Background()
+	statuses := make([]core.BandStatus, core.NumPriorityBands)
+
+	for band := 0; band < core.NumPriorityBands; band++ {
+		depth, err := q.client.LLen(ctx, q.pendingKey(band)).Result()
+		if err != nil {
+			log.Printf("redis queue: failed to read depth of band %d: %v", band, err)
+		}
+
+		statuses[band] = core.BandStatus{Priority: band, Current: int(depth)}
+	}
+
+	return statuses
+}
+
+// Heartbeat records that taskID is still being actively processed,
+// refreshing its heartbeat key's TTL. It satisfies core.Heartbeater.
+func (q *RedisQueue) Heartbeat(taskID string) error {
+	return q.client.Set(context.Background(), q.heartbeatKey(taskID), time.Now().Unix(), q.heartbeatTTL).Err()
+}
+
+// Ack records that taskID has finished processing, removing it from this
+// instance's processing list so reclaimDue no longer considers it
+// in-flight. Without this, a task's heartbeat key simply expires once its
+// worker stops heartbeating at completion, and the next reclaim tick
+// mistakes that for a crashed worker and redelivers the already-finished
+// task. It satisfies core.Acker.
+func (q *RedisQueue) Ack(taskID string) error {
+	ctx := context.Background()
+
+	if err := q.client.LRem(ctx, q.processingKey(), 0, taskID).Err(); err != nil {
+		return err
+	}
+
+	return q.client.Del(ctx, q.heartbeatKey(taskID)).Err()
+}
+
+// dispatch feeds q.out by weighted round robin across the priority
+// bands, the same weights TaskQueue uses, BRPOPLPUSHing each band's
+// pending list into this instance's processing list so a claimed task
+// isn't lost if this process dies before handing it to a worker.
+func (q *RedisQueue) dispatch() {
+	for {
+		select {
+		case <-q.quit:
+			close(q.out)
+			return
+		default:
+		}
+
+		dispatchedAny := false
+
+		for band := 0; band < core.NumPriorityBands; band++ {
+			weight := core.PriorityBandWeights[band]
+
+		bandLoop:
+			for i := 0; i < weight; i++ {
+				task, ok, err := q.popOne(band)
+				if err != nil {
+					log.Printf("redis queue: band %d pop failed: %v", band, err)
+					break bandLoop
+				}
+				if !ok {
+					break bandLoop
+				}
+
+				select {
+				case q.out <- task:
+					dispatchedAny = true
+				case <-q.quit:
+					close(q.out)
+					return
+				}
+			}
+		}
+
+		if !dispatchedAny {
+			select {
+			case <-time.After(time.Millisecond):
+			case <-q.quit:
+				close(q.out)
+				return
+			}
+		}
+	}
+}
+
+// popOne claims at most one task off band's pending list, returning
+// ok=false if the band was empty. The short BRPOPLPUSH timeout keeps
+// dispatch responsive to Close and to the other bands.
+func (q *RedisQueue) popOne(band int) (core.Task, bool, error) {
+	ctx := context.Background()
+
+	taskID, err := q.client.BRPopLPush(ctx, q.pendingKey(band), q.processingKey(), 100*time.Millisecond).Result()
+	if err == redis.Nil {
+		return core.Task{}, false, nil
+	}
+	if err != nil {
+		return core.Task{}, false, err
+	}
+
+	raw, err := q.client.Get(ctx, q.taskKey(taskID)).Bytes()
+	if err != nil {
+		return core.Task{}, false, err
+	}
+
+	var task core.Task
+	if err := json.Unmarshal(raw, &task); err != nil {
+		return core.Task{}, false, err
+	}
+
+	if err := q.Heartbeat(taskID); err != nil {
+		log.Printf("redis queue: failed to set initial heartbeat for task %s: %v", taskID, err)
+	}
+
+	return task, true, nil
+}
+
+// reclaim runs reclaimScript against every task on this instance's
+// processing list at reclaimInterval, moving back onto their pending
+// list any whose heartbeat key has expired.
+func (q *RedisQueue) reclaim() {
+	ticker := time.NewTicker(q.reclaimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			q.reclaimDue()
+		case <-q.quit:
+			return
+		}
+	}
+}
+
+func (q *RedisQueue) reclaimDue() {
+	ctx := context.Background()
+
+	ids, err := q.client.LRange(ctx, q.processingKey(), 0, -1).Result()
+	if err != nil {
+		log.Printf("redis queue: failed to list in-progress tasks: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		raw, err := q.client.Get(ctx, q.taskKey(id)).Bytes()
+		if err != nil {
+			log.Printf("redis queue: failed to load in-progress task %s: %v", id, err)
+			continue
+		}
+
+		var task core.Task
+		if err := json.Unmarshal(raw, &task); err != nil {
+			log.Printf("redis queue: failed to unmarshal in-progress task %s: %v", id, err)
+			continue
+		}
+
+		pendingKey := q.pendingKey(core.PriorityBand(task.Priority))
+
+		if err := reclaimScript.Run(ctx, q.client, []string{q.processingKey(), q.heartbeatKey(id), pendingKey}, id).Err(); err != nil {
+			log.Printf("redis queue: failed to reclaim task %s: %v", id, err)
+		}
+	}
+}
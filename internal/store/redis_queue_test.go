@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AdonaIsium/TaskEngine/internal/core"
+)
+
+func TestRedisQueue_SubmitAndDispatch(t *testing.T) {
+	client := newTestRedisClient(t)
+
+	q := NewRedisQueue(client, "", "worker-1", time.Minute, time.Hour)
+	defer q.Close()
+
+	task := newTestTask(t, "task_1", core.CPU_INTENSIVE)
+	task.Priority = core.NumPriorityBands - 1 // band 0, checked first each dispatch cycle
+	if err := q.Submit(task); err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+
+	select {
+	case got := <-q.GetTaskChannel():
+		if got.ID != task.ID {
+			t.Errorf("expected task %s, got %s", task.ID, got.ID)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for dispatched task")
+	}
+}
+
+func TestRedisQueue_Ack_RemovesFromProcessingList(t *testing.T) {
+	client := newTestRedisClient(t)
+
+	q := NewRedisQueue(client, "", "worker-1", time.Minute, time.Hour)
+	defer q.Close()
+
+	task := newTestTask(t, "task_2", core.CPU_INTENSIVE)
+	task.Priority = core.NumPriorityBands - 1
+	if err := q.Submit(task); err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+
+	<-q.GetTaskChannel()
+
+	if n, err := client.LLen(context.Background(), q.processingKey()).Result(); err != nil {
+		t.Fatalf("LLen returned error: %v", err)
+	} else if n != 1 {
+		t.Fatalf("expected 1 task on processing list before Ack, got %d", n)
+	}
+
+	if err := q.Ack(task.ID); err != nil {
+		t.Fatalf("Ack returned error: %v", err)
+	}
+
+	n, err := client.LLen(context.Background(), q.processingKey()).Result()
+	if err != nil {
+		t.Fatalf("LLen returned error: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected Ack to remove task from processing list, still has %d entries", n)
+	}
+}
+
+func TestRedisQueue_Reclaim_DoesNotRedeliverAckedTask(t *testing.T) {
+	client := newTestRedisClient(t)
+
+	// A reclaimInterval short enough to fire at least once during the test,
+	// and a heartbeatTTL short enough to have already lapsed by then.
+	q := NewRedisQueue(client, "", "worker-1", 10*time.Millisecond, 20*time.Millisecond)
+	defer q.Close()
+
+	task := newTestTask(t, "task_3", core.CPU_INTENSIVE)
+	task.Priority = core.NumPriorityBands - 1
+	if err := q.Submit(task); err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+
+	<-q.GetTaskChannel()
+
+	if err := q.Ack(task.ID); err != nil {
+		t.Fatalf("Ack returned error: %v", err)
+	}
+
+	select {
+	case redelivered := <-q.GetTaskChannel():
+		t.Fatalf("expected acked task %s not to be redelivered, got %s", task.ID, redelivered.ID)
+	case <-time.After(150 * time.Millisecond):
+	}
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/AdonaIsium/TaskEngine/internal/core"
+)
+
+func newTestRedisClient(t *testing.T) *redis.Client {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	return redis.NewClient(&redis.Options{Addr: mr.Addr()})
+}
+
+func TestRedisStore_SaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	s := NewRedisStore(newTestRedisClient(t), "")
+
+	task := newTestTask(t, "task_1", core.CPU_INTENSIVE)
+
+	if err := s.Save(ctx, task); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	rec, err := s.Get(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if rec.Task.ID != task.ID {
+		t.Errorf("expected task ID %s, got %s", task.ID, rec.Task.ID)
+	}
+}
+
+func TestRedisStore_Get_NotFound(t *testing.T) {
+	s := NewRedisStore(newTestRedisClient(t), "")
+
+	_, err := s.Get(context.Background(), "does_not_exist")
+	if err != core.ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestRedisStore_UpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	s := NewRedisStore(newTestRedisClient(t), "")
+
+	task := newTestTask(t, "task_2", core.IO_BOUND)
+	if err := s.Save(ctx, task); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if err := s.UpdateStatus(ctx, task.ID, core.PROCESSING); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+
+	rec, err := s.Get(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if rec.Task.Status != core.PROCESSING {
+		t.Errorf("expected status PROCESSING, got %v", rec.Task.Status)
+	}
+}
+
+func TestRedisStore_ListByStatus(t *testing.T) {
+	ctx := context.Background()
+	s := NewRedisStore(newTestRedisClient(t), "")
+
+	pending := newTestTask(t, "task_pending", core.CPU_INTENSIVE)
+	processing := newTestTask(t, "task_processing", core.CPU_INTENSIVE)
+
+	if err := s.Save(ctx, pending); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := s.Save(ctx, processing); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := s.UpdateStatus(ctx, processing.ID, core.PROCESSING); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+
+	results, err := s.ListByStatus(ctx, core.PENDING)
+	if err != nil {
+		t.Fatalf("ListByStatus returned error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Task.ID != pending.ID {
+		t.Errorf("expected only %s in PENDING, got %+v", pending.ID, results)
+	}
+}
+
+func TestRedisStore_Cancel(t *testing.T) {
+	ctx := context.Background()
+	s := NewRedisStore(newTestRedisClient(t), "")
+
+	task := newTestTask(t, "task_cancel", core.TIME_BASED)
+	if err := s.Save(ctx, task); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if err := s.Cancel(ctx, task.ID); err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+
+	rec, err := s.Get(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if rec.Task.Status != core.CANCELED {
+		t.Errorf("expected status CANCELED, got %v", rec.Task.Status)
+	}
+}